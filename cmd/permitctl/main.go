@@ -0,0 +1,190 @@
+// Command permitctl is a TLS gRPC client for permitd, driven by a single
+// subcommand and its arguments.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/ursa-mikail/double_linked_list_chained_permit/proto/permitpb"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:9443", "permitd address")
+	certFile := flag.String("cert", "client.crt", "client TLS certificate")
+	keyFile := flag.String("key", "client.key", "client TLS private key")
+	caFile := flag.String("ca", "ca.crt", "CA certificate used to verify the server")
+	callerID := flag.String("caller", "", "calling permit's node ID (required in permissioned mode)")
+	requiredDomain := flag.String("domain", "", "domain the caller is acting under")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		log.Fatalf("permitctl: usage: permitctl [flags] <create|insert-at|get|list|update-domains|rotate|bulk-rotate|revoke|restore|delete|watch> [args...]")
+	}
+
+	cert, err := tls.LoadX509KeyPair(*certFile, *keyFile)
+	if err != nil {
+		log.Fatalf("permitctl: load client certificate: %v", err)
+	}
+
+	caCert, err := os.ReadFile(*caFile)
+	if err != nil {
+		log.Fatalf("permitctl: read CA certificate: %v", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCert) {
+		log.Fatalf("permitctl: no certificates found in %s", *caFile)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      caPool,
+	}
+
+	conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		log.Fatalf("permitctl: dial %s: %v", *addr, err)
+	}
+	defer conn.Close()
+
+	client := permitpb.NewPermitServiceClient(conn)
+	caller := &permitpb.CallerContext{CallerID: *callerID, RequiredDomain: *requiredDomain}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	switch cmd, rest := args[0], args[1:]; cmd {
+	case "create":
+		if len(rest) < 1 {
+			log.Fatalf("permitctl: usage: create <domain> [domain...]")
+		}
+		reply, err := client.CreatePermit(ctx, &permitpb.CreatePermitRequest{Caller: caller, Domains: rest})
+		must(err)
+		printReply(reply)
+
+	case "insert-at":
+		if len(rest) < 2 {
+			log.Fatalf("permitctl: usage: insert-at <position> <domain> [domain...]")
+		}
+		position, err := strconv.Atoi(rest[0])
+		if err != nil {
+			log.Fatalf("permitctl: invalid position %q: %v", rest[0], err)
+		}
+		reply, err := client.InsertAt(ctx, &permitpb.InsertAtRequest{Caller: caller, Domains: rest[1:], Position: int32(position)})
+		must(err)
+		printReply(reply)
+
+	case "get":
+		if len(rest) != 1 {
+			log.Fatalf("permitctl: usage: get <node-id>")
+		}
+		reply, err := client.GetPermit(ctx, &permitpb.GetPermitRequest{NodeID: rest[0]})
+		must(err)
+		printReply(reply)
+
+	case "list":
+		if len(rest) != 1 {
+			log.Fatalf("permitctl: usage: list <domain>")
+		}
+		reply, err := client.ListByDomain(ctx, &permitpb.ListByDomainRequest{Domain: rest[0]})
+		must(err)
+		for _, p := range reply.Permits {
+			printReply(p)
+		}
+
+	case "update-domains":
+		if len(rest) < 2 {
+			log.Fatalf("permitctl: usage: update-domains <node-id> <domain> [domain...]")
+		}
+		reply, err := client.UpdateDomains(ctx, &permitpb.UpdateDomainsRequest{Caller: caller, NodeID: rest[0], Domains: rest[1:]})
+		must(err)
+		printStatus(reply)
+
+	case "rotate":
+		if len(rest) != 1 {
+			log.Fatalf("permitctl: usage: rotate <node-id>")
+		}
+		reply, err := client.RotateKey(ctx, &permitpb.NodeRequest{Caller: caller, NodeID: rest[0]})
+		must(err)
+		printStatus(reply)
+
+	case "bulk-rotate":
+		if len(rest) > 1 {
+			log.Fatalf("permitctl: usage: bulk-rotate [domain]")
+		}
+		req := &permitpb.BulkRotateKeysRequest{Caller: caller}
+		if len(rest) == 1 {
+			req.Domain = &rest[0]
+		}
+		reply, err := client.BulkRotateKeys(ctx, req)
+		must(err)
+		fmt.Printf("rotated %d permit(s)\n", reply.RotatedCount)
+
+	case "revoke":
+		if len(rest) != 1 {
+			log.Fatalf("permitctl: usage: revoke <node-id>")
+		}
+		reply, err := client.Revoke(ctx, &permitpb.NodeRequest{Caller: caller, NodeID: rest[0]})
+		must(err)
+		printStatus(reply)
+
+	case "restore":
+		if len(rest) != 1 {
+			log.Fatalf("permitctl: usage: restore <node-id>")
+		}
+		reply, err := client.Restore(ctx, &permitpb.NodeRequest{Caller: caller, NodeID: rest[0]})
+		must(err)
+		printStatus(reply)
+
+	case "delete":
+		if len(rest) != 1 {
+			log.Fatalf("permitctl: usage: delete <node-id>")
+		}
+		reply, err := client.Delete(ctx, &permitpb.NodeRequest{Caller: caller, NodeID: rest[0]})
+		must(err)
+		printStatus(reply)
+
+	case "watch":
+		stream, err := client.WatchEvents(context.Background(), &permitpb.WatchEventsRequest{})
+		must(err)
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				log.Fatalf("permitctl: watch: %v", err)
+			}
+			fmt.Printf("%s node=%s domain=%s at=%.6f\n", event.Op, event.NodeID, event.Domain, event.Timestamp)
+		}
+
+	default:
+		log.Fatalf("permitctl: unknown subcommand %q", cmd)
+	}
+}
+
+func must(err error) {
+	if err != nil {
+		log.Fatalf("permitctl: %v", err)
+	}
+}
+
+func printReply(reply *permitpb.PermitReply) {
+	fmt.Printf("node=%s domains=%v revoked=%v timestamp=%.6f\n", reply.NodeID, reply.Domains, reply.Revoked, reply.Timestamp)
+}
+
+func printStatus(reply *permitpb.StatusReply) {
+	if reply.Ok {
+		fmt.Println("ok")
+		return
+	}
+	fmt.Printf("error: %s\n", reply.Error)
+}
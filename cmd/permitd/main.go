@@ -0,0 +1,70 @@
+// Command permitd serves a PermitLinkedList over gRPC, authenticating
+// clients by their TLS client certificate.
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"log"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/ursa-mikail/double_linked_list_chained_permit/permit"
+	"github.com/ursa-mikail/double_linked_list_chained_permit/proto/permitpb"
+)
+
+func main() {
+	addr := flag.String("addr", ":9443", "address to listen on")
+	certFile := flag.String("cert", "server.crt", "server TLS certificate")
+	keyFile := flag.String("key", "server.key", "server TLS private key")
+	caFile := flag.String("ca", "ca.crt", "CA certificate used to verify client certificates")
+	eventLogPath := flag.String("event-log", "", "path to an append-only event log (disabled if empty)")
+	flag.Parse()
+
+	cert, err := tls.LoadX509KeyPair(*certFile, *keyFile)
+	if err != nil {
+		log.Fatalf("permitd: load server certificate: %v", err)
+	}
+
+	caCert, err := os.ReadFile(*caFile)
+	if err != nil {
+		log.Fatalf("permitd: read CA certificate: %v", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCert) {
+		log.Fatalf("permitd: no certificates found in %s", *caFile)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+
+	var pll *permit.PermitLinkedList
+	if *eventLogPath != "" {
+		pll, err = permit.NewPermitLinkedListFromLog(*eventLogPath)
+		if err != nil {
+			log.Fatalf("permitd: recover from event log: %v", err)
+		}
+	} else {
+		pll = permit.NewPermitLinkedList()
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("permitd: listen on %s: %v", *addr, err)
+	}
+
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	permitpb.RegisterPermitServiceServer(grpcServer, permit.NewPermitGRPCService(pll))
+
+	log.Printf("permitd: listening on %s", *addr)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("permitd: serve: %v", err)
+	}
+}
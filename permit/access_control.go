@@ -0,0 +1,39 @@
+package permit
+
+import "errors"
+
+// Errors returned by the AccessController when a caller is not permitted
+// to perform a mutating operation.
+var (
+	ErrPermitRevoked       = errors.New("access_control: caller permit is revoked")
+	ErrDomainNotAuthorized = errors.New("access_control: caller lacks the required domain")
+	ErrUnknownCaller       = errors.New("access_control: caller permit not found")
+)
+
+// AccessController gates mutating operations on a PermitLinkedList behind a
+// caller's own permit. A caller is authorized for a domain if its permit is
+// active and contains that domain, or the configured admin domain.
+type AccessController struct {
+	AdminDomain Domain
+}
+
+// authorize checks that callerID names an active permit holding either
+// required or the admin domain. When the list is not in PermissionedMode,
+// authorize always succeeds.
+func (pll *PermitLinkedList) authorize(callerID string, required Domain) error {
+	if !pll.PermissionedMode {
+		return nil
+	}
+
+	caller, ok := pll.NodeMap[callerID]
+	if !ok {
+		return ErrUnknownCaller
+	}
+	if caller.Permit.Revoked {
+		return ErrPermitRevoked
+	}
+	if caller.Permit.Domains[required] || caller.Permit.Domains[pll.AccessController.AdminDomain] {
+		return nil
+	}
+	return ErrDomainNotAuthorized
+}
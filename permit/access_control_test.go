@@ -0,0 +1,92 @@
+package permit
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAuthorizeAllowsEveryoneWhenNotPermissioned(t *testing.T) {
+	pll := NewPermitLinkedList()
+	node := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+
+	if err := pll.RotateKey("", "", node.ID); err != nil {
+		t.Fatalf("RotateKey in non-permissioned mode: %v", err)
+	}
+}
+
+func TestAuthorizeAllowsCallerHoldingRequiredDomain(t *testing.T) {
+	pll := NewPermissionedPermitLinkedList(DomainIT)
+	caller := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+	target := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+
+	if err := pll.RotateKey(caller.ID, DomainFinance, target.ID); err != nil {
+		t.Fatalf("RotateKey with required domain: %v", err)
+	}
+}
+
+func TestAuthorizeAllowsAdminDomainRegardlessOfRequiredDomain(t *testing.T) {
+	pll := NewPermissionedPermitLinkedList(DomainIT)
+	admin := pll.CreatePermit(map[Domain]bool{DomainIT: true})
+	target := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+
+	if err := pll.RotateKey(admin.ID, DomainFinance, target.ID); err != nil {
+		t.Fatalf("RotateKey as admin: %v", err)
+	}
+}
+
+func TestAuthorizeRejectsCallerLackingRequiredDomain(t *testing.T) {
+	pll := NewPermissionedPermitLinkedList(DomainIT)
+	attacker := pll.CreatePermit(map[Domain]bool{DomainHR: true})
+	target := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+
+	err := pll.RotateKey(attacker.ID, DomainFinance, target.ID)
+	if !errors.Is(err, ErrDomainNotAuthorized) {
+		t.Fatalf("RotateKey by unauthorized caller: got %v, want ErrDomainNotAuthorized", err)
+	}
+}
+
+func TestAuthorizeRejectsUnknownCaller(t *testing.T) {
+	pll := NewPermissionedPermitLinkedList(DomainIT)
+	target := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+
+	err := pll.RotateKey("nonexistent", DomainFinance, target.ID)
+	if !errors.Is(err, ErrUnknownCaller) {
+		t.Fatalf("RotateKey by unknown caller: got %v, want ErrUnknownCaller", err)
+	}
+}
+
+func TestAuthorizeRejectsRevokedCaller(t *testing.T) {
+	pll := NewPermissionedPermitLinkedList(DomainIT)
+	admin := pll.CreatePermit(map[Domain]bool{DomainIT: true})
+	caller := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+	target := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+	if err := pll.RevokePermit(admin.ID, DomainIT, caller.ID); err != nil {
+		t.Fatalf("revoke caller's permit: %v", err)
+	}
+
+	err := pll.RotateKey(caller.ID, DomainFinance, target.ID)
+	if !errors.Is(err, ErrPermitRevoked) {
+		t.Fatalf("RotateKey by revoked caller: got %v, want ErrPermitRevoked", err)
+	}
+}
+
+// TestBulkRotateKeysEnforcesAuthorization guards against BulkRotateKeys
+// bypassing the same gate the other gated mutators enforce.
+func TestBulkRotateKeysEnforcesAuthorization(t *testing.T) {
+	pll := NewPermissionedPermitLinkedList(DomainIT)
+	attacker := pll.CreatePermit(map[Domain]bool{DomainHR: true})
+	pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+
+	if _, err := pll.BulkRotateKeys(attacker.ID, DomainFinance, nil); !errors.Is(err, ErrDomainNotAuthorized) {
+		t.Fatalf("BulkRotateKeys by unauthorized caller: got %v, want ErrDomainNotAuthorized", err)
+	}
+
+	admin := pll.CreatePermit(map[Domain]bool{DomainIT: true})
+	count, err := pll.BulkRotateKeys(admin.ID, DomainIT, nil)
+	if err != nil {
+		t.Fatalf("BulkRotateKeys by admin: %v", err)
+	}
+	if count == 0 {
+		t.Fatal("BulkRotateKeys by admin rotated 0 permits, want at least 1")
+	}
+}
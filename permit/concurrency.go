@@ -0,0 +1,95 @@
+package permit
+
+// indexAdd adds node to the DomainIndex under each of its current domains.
+// Callers must already hold pll.mu for writing.
+func (pll *PermitLinkedList) indexAdd(node *PermitNode) {
+	for d := range node.Permit.Domains {
+		idx, ok := pll.DomainIndex[d]
+		if !ok {
+			idx = make(map[string]*PermitNode)
+			pll.DomainIndex[d] = idx
+		}
+		idx[node.ID] = node
+	}
+}
+
+// indexRemoveDomains removes nodeID from the DomainIndex under each of the
+// given domains, pruning any domain bucket left empty. Callers must already
+// hold pll.mu for writing.
+func (pll *PermitLinkedList) indexRemoveDomains(nodeID string, domains map[Domain]bool) {
+	for d := range domains {
+		idx, ok := pll.DomainIndex[d]
+		if !ok {
+			continue
+		}
+		delete(idx, nodeID)
+		if len(idx) == 0 {
+			delete(pll.DomainIndex, d)
+		}
+	}
+}
+
+// removeNode unlinks node from the list, the NodeMap, and the DomainIndex.
+// Callers must already hold pll.mu for writing and must own the node (i.e.
+// it came from pll.NodeMap or a list traversal).
+func (pll *PermitLinkedList) removeNode(node *PermitNode) {
+	if node.Prev != nil {
+		node.Prev.Next = node.Next
+	} else {
+		pll.Head = node.Next
+	}
+	if node.Next != nil {
+		node.Next.Prev = node.Prev
+	} else {
+		pll.Tail = node.Prev
+	}
+
+	delete(pll.NodeMap, node.ID)
+	pll.indexRemoveDomains(node.ID, node.Permit.Domains)
+	pll.Size--
+}
+
+// PermitView is a read-only, concurrency-safe view of a single permit,
+// detached from the live list so holding onto it cannot race with further
+// mutations.
+type PermitView struct {
+	ID        string
+	Domains   []string
+	Revoked   bool
+	Timestamp float64
+}
+
+// ListSnapshot is a read-only, concurrency-safe view of an entire
+// PermitLinkedList at the moment Snapshot was called.
+type ListSnapshot struct {
+	Size  int
+	Nodes []PermitView
+}
+
+// viewOf detaches node into a PermitView, safe to hand to a caller that will
+// read it outside pll.mu. Callers must already hold pll.mu for reading.
+func (pll *PermitLinkedList) viewOf(node *PermitNode) PermitView {
+	domainNames := make([]string, 0, len(node.Permit.Domains))
+	for d := range node.Permit.Domains {
+		domainNames = append(domainNames, string(d))
+	}
+	return PermitView{
+		ID:        node.ID,
+		Domains:   domainNames,
+		Revoked:   node.Permit.Revoked,
+		Timestamp: node.Permit.Timestamp,
+	}
+}
+
+// Snapshot returns a read-only copy of the list's current state, safe to
+// read from any goroutine without holding pll.mu.
+func (pll *PermitLinkedList) Snapshot() ListSnapshot {
+	pll.mu.RLock()
+	defer pll.mu.RUnlock()
+
+	nodes := make([]PermitView, 0, pll.Size)
+	for current := pll.Head; current != nil; current = current.Next {
+		nodes = append(nodes, pll.viewOf(current))
+	}
+	return ListSnapshot{Size: pll.Size, Nodes: nodes}
+}
@@ -0,0 +1,65 @@
+package permit
+
+import (
+	"fmt"
+	"testing"
+)
+
+var benchSizes = []int{10_000, 100_000, 1_000_000}
+
+func populatedList(size int) *PermitLinkedList {
+	pll := NewPermitLinkedList()
+	for i := 0; i < size; i++ {
+		pll.CreatePermit(GetRandomDomains(2))
+	}
+	return pll
+}
+
+// BenchmarkFindByDomain measures DomainIndex lookup cost, which scales with
+// the number of matching entries rather than the list size. Each permit here
+// holds 2 of the 10 domains, so about a fifth of the list matches and the
+// result count grows with size.
+func BenchmarkFindByDomain(b *testing.B) {
+	for _, size := range benchSizes {
+		pll := populatedList(size)
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				pll.FindByDomain(DomainFinance)
+			}
+		})
+	}
+}
+
+// BenchmarkBulkRotate measures the cost of rotating every active permit's
+// key, which is necessarily O(n) in list size.
+func BenchmarkBulkRotate(b *testing.B) {
+	for _, size := range benchSizes {
+		pll := populatedList(size)
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				pll.BulkRotateKeys("", "", nil)
+			}
+		})
+	}
+}
+
+// BenchmarkConcurrentCRUD measures throughput under concurrent readers and
+// writers, exercising the sync.RWMutex and DomainIndex together.
+func BenchmarkConcurrentCRUD(b *testing.B) {
+	for _, size := range benchSizes {
+		pll := populatedList(size)
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					node := pll.CreatePermit(GetRandomDomains(1))
+					pll.FindByDomain(DomainFinance)
+					pll.RotateKey("", "", node.ID)
+					pll.DeletePermit("", "", node.ID)
+				}
+			})
+		})
+	}
+}
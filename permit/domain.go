@@ -0,0 +1,83 @@
+package permit
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+
+	"github.com/jaswdr/faker"
+)
+
+// Domain represents different business domains
+type Domain string
+
+const (
+	DomainFinance     Domain = "finance"
+	DomainAIML        Domain = "ai_ml"
+	DomainHR          Domain = "hr"
+	DomainIT          Domain = "it"
+	DomainMarketing   Domain = "marketing"
+	DomainSales       Domain = "sales"
+	DomainOperations  Domain = "operations"
+	DomainLegal       Domain = "legal"
+	DomainResearch    Domain = "research"
+	DomainDevelopment Domain = "development"
+)
+
+var allDomains = []Domain{
+	DomainFinance, DomainAIML, DomainHR, DomainIT, DomainMarketing,
+	DomainSales, DomainOperations, DomainLegal, DomainResearch, DomainDevelopment,
+}
+
+// GetRandomDomain returns a random domain
+func GetRandomDomain() Domain {
+	n, _ := rand.Int(rand.Reader, big.NewInt(int64(len(allDomains))))
+	return allDomains[n.Int64()]
+}
+
+// GetRandomDomains returns a set of unique random domains
+func GetRandomDomains(count int) map[Domain]bool {
+	if count > len(allDomains) {
+		count = len(allDomains)
+	}
+
+	domains := make(map[Domain]bool)
+	for len(domains) < count {
+		domains[GetRandomDomain()] = true
+	}
+	return domains
+}
+
+// CreateFakeDepartments creates random department domains using Faker
+func CreateFakeDepartments(count int) []Domain {
+	fake := faker.New()
+	departmentMapping := map[string]Domain{
+		"finance":                DomainFinance,
+		"accounting":             DomainFinance,
+		"human resources":        DomainHR,
+		"hr":                     DomainHR,
+		"information technology": DomainIT,
+		"it":                     DomainIT,
+		"marketing":              DomainMarketing,
+		"sales":                  DomainSales,
+		"operations":             DomainOperations,
+		"legal":                  DomainLegal,
+		"research":               DomainResearch,
+		"development":            DomainDevelopment,
+		"ai":                     DomainAIML,
+		"machine learning":       DomainAIML,
+		"data science":           DomainAIML,
+	}
+
+	departments := make([]Domain, count)
+	for i := 0; i < count; i++ {
+		deptName := strings.ToLower(fake.Lorem().Word())
+		if domain, ok := departmentMapping[deptName]; ok {
+			departments[i] = domain
+		} else {
+			departments[i] = GetRandomDomain()
+		}
+	}
+
+	return departments
+}
@@ -0,0 +1,383 @@
+package permit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// EventType identifies the kind of mutating call an EventLog entry records.
+type EventType string
+
+const (
+	EventCreatePermit  EventType = "create_permit"
+	EventInsertPermit  EventType = "insert_permit"
+	EventUpdateDomains EventType = "update_domains"
+	EventRotateKey     EventType = "rotate_key"
+	EventRevokePermit  EventType = "revoke_permit"
+	EventRestorePermit EventType = "restore_permit"
+	EventDeletePermit  EventType = "delete_permit"
+)
+
+// PermitSnapshot is a forensic-log-safe view of a Permit: the raw key is
+// redacted to its SHA-256 hash so the log never carries key material.
+type PermitSnapshot struct {
+	Domains       []string `json:"domains"`
+	KeyHash       string   `json:"key_hash"`
+	Timestamp     float64  `json:"timestamp"`
+	Revoked       bool     `json:"revoked"`
+	LastRotated   float64  `json:"last_rotated"`
+	RotationCount int      `json:"rotation_count"`
+}
+
+func snapshotPermit(p *Permit) *PermitSnapshot {
+	if p == nil {
+		return nil
+	}
+	domainNames := make([]string, 0, len(p.Domains))
+	for d := range p.Domains {
+		domainNames = append(domainNames, string(d))
+	}
+	sort.Strings(domainNames)
+
+	hash := sha256.Sum256(p.Key)
+	return &PermitSnapshot{
+		Domains:       domainNames,
+		KeyHash:       hex.EncodeToString(hash[:]),
+		Timestamp:     p.Timestamp,
+		Revoked:       p.Revoked,
+		LastRotated:   p.LastRotated,
+		RotationCount: p.RotationCount,
+	}
+}
+
+// EventLogEntry is one append-only JSON-lines record of a mutating call.
+type EventLogEntry struct {
+	Seq       int64           `json:"seq"`
+	Timestamp float64         `json:"timestamp"`
+	Op        EventType       `json:"op"`
+	NodeID    string          `json:"node_id"`
+	Position  *int            `json:"position,omitempty"`
+	Before    *PermitSnapshot `json:"before,omitempty"`
+	After     *PermitSnapshot `json:"after,omitempty"`
+}
+
+// EventLog is an append-only JSON-lines audit log of every mutating call
+// made against a PermitLinkedList, sufficient to deterministically rebuild
+// the list's structure via replay. Key bytes are never written to disk;
+// only a SHA-256 hash of each key is recorded.
+type EventLog struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	seq  int64
+}
+
+// NewEventLog opens (creating if necessary) an append-only event log at path.
+func NewEventLog(path string) (*EventLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("event log: open %s: %w", path, err)
+	}
+	return &EventLog{path: path, file: f}, nil
+}
+
+// Close releases the underlying file handle.
+func (el *EventLog) Close() error {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	return el.file.Close()
+}
+
+// append writes one entry to the log with the next monotonic sequence
+// number, flushing immediately so the log stays durable across crashes.
+func (el *EventLog) append(op EventType, nodeID string, position *int, before, after *Permit) error {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	el.seq++
+	entry := EventLogEntry{
+		Seq:       el.seq,
+		Timestamp: getCurrentTimestamp(),
+		Op:        op,
+		NodeID:    nodeID,
+		Position:  position,
+		Before:    snapshotPermit(before),
+		After:     snapshotPermit(after),
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("event log: marshal entry: %w", err)
+	}
+	line = append(line, '\n')
+	if _, err := el.file.Write(line); err != nil {
+		return fmt.Errorf("event log: write entry: %w", err)
+	}
+	return el.file.Sync()
+}
+
+// logEvent appends an event if the list has an attached EventLog, otherwise
+// it is a no-op so PermissionedMode-only lists pay no durability cost.
+func (pll *PermitLinkedList) logEvent(op EventType, nodeID string, position *int, before, after *Permit) {
+	if pll.EventLog == nil {
+		return
+	}
+	if err := pll.EventLog.append(op, nodeID, position, before, after); err != nil {
+		fmt.Printf("event log: failed to record %s for node %s: %v\n", op, nodeID, err)
+	}
+}
+
+// domainSnapshotToMap turns a replayed PermitSnapshot's domain list back
+// into the map[Domain]bool representation the list operates on.
+func domainSnapshotToMap(domains []string) map[Domain]bool {
+	result := make(map[Domain]bool, len(domains))
+	for _, d := range domains {
+		result[Domain(d)] = true
+	}
+	return result
+}
+
+// replayCreate inserts a node at the tail with a caller-specified ID,
+// domains, and original timestamp/rotation state, bypassing ID generation
+// and access control. Used only by log replay, where these must come from
+// the logged entry, not wall-clock time or zero values, for replay to be
+// faithful — in particular after Compact, a create_permit entry stands in
+// for a permit's full history and carries its accumulated LastRotated and
+// RotationCount rather than the values a fresh create would have.
+func (pll *PermitLinkedList) replayCreate(id string, domains map[Domain]bool, timestamp, lastRotated float64, rotationCount int) *PermitNode {
+	node := &PermitNode{
+		Permit: &Permit{Domains: domains, Key: pll.GenerateKey(), Timestamp: timestamp, LastRotated: lastRotated, RotationCount: rotationCount},
+		ID:     id,
+	}
+	if pll.Head == nil {
+		pll.Head = node
+		pll.Tail = node
+	} else {
+		node.Prev = pll.Tail
+		pll.Tail.Next = node
+		pll.Tail = node
+	}
+	pll.Size++
+	pll.NodeMap[node.ID] = node
+	pll.indexAdd(node)
+	return node
+}
+
+// replayInsert inserts a node at position with a caller-specified ID and
+// original timestamp/rotation state, mirroring InsertPermitAtPosition but
+// bypassing ID generation, access control, and logging. Used only by log
+// replay, where these must come from the logged entry, not wall-clock time
+// or zero values, for replay to be faithful (see replayCreate).
+func (pll *PermitLinkedList) replayInsert(id string, domains map[Domain]bool, position int, timestamp, lastRotated float64, rotationCount int) *PermitNode {
+	node := &PermitNode{
+		Permit: &Permit{Domains: domains, Key: pll.GenerateKey(), Timestamp: timestamp, LastRotated: lastRotated, RotationCount: rotationCount},
+		ID:     id,
+	}
+
+	if position <= 0 {
+		node.Next = pll.Head
+		if pll.Head != nil {
+			pll.Head.Prev = node
+		}
+		pll.Head = node
+		if pll.Tail == nil {
+			pll.Tail = node
+		}
+	} else if position >= pll.Size {
+		node.Prev = pll.Tail
+		if pll.Tail != nil {
+			pll.Tail.Next = node
+		}
+		pll.Tail = node
+	} else {
+		current := pll.Head
+		for i := 0; i < position; i++ {
+			current = current.Next
+		}
+		node.Prev = current.Prev
+		node.Next = current
+		if current.Prev != nil {
+			current.Prev.Next = node
+		}
+		current.Prev = node
+	}
+
+	pll.Size++
+	pll.NodeMap[node.ID] = node
+	pll.indexAdd(node)
+	return node
+}
+
+// NewPermitLinkedListFromLog rebuilds a PermitLinkedList by replaying an
+// EventLog file from the start. If path doesn't exist yet, it's treated as
+// an empty log: NewPermitLinkedListFromLog returns an empty list and
+// creates the file, so a fresh deployment can turn on durability by just
+// pointing at a path instead of first touch-ing it by hand. Key bytes are
+// not recoverable from the log (only their hash is recorded), so replayed
+// permits carry freshly generated keys rather than the originals;
+// structure, domains, positions, and revocation state are reproduced
+// exactly.
+func NewPermitLinkedListFromLog(path string) (*PermitLinkedList, error) {
+	pll := NewPermitLinkedList()
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return finishFromLog(pll, path, 0)
+		}
+		return nil, fmt.Errorf("event log: open %s for replay: %w", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var maxSeq int64
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var entry EventLogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("event log: decode entry: %w", err)
+		}
+		if entry.Seq > maxSeq {
+			maxSeq = entry.Seq
+		}
+
+		switch entry.Op {
+		case EventCreatePermit:
+			pll.replayCreate(entry.NodeID, domainSnapshotToMap(entry.After.Domains), entry.After.Timestamp, entry.After.LastRotated, entry.After.RotationCount)
+		case EventInsertPermit:
+			position := pll.Size
+			if entry.Position != nil {
+				position = *entry.Position
+			}
+			pll.replayInsert(entry.NodeID, domainSnapshotToMap(entry.After.Domains), position, entry.After.Timestamp, entry.After.LastRotated, entry.After.RotationCount)
+		case EventUpdateDomains:
+			if node, ok := pll.NodeMap[entry.NodeID]; ok {
+				pll.indexRemoveDomains(node.ID, node.Permit.Domains)
+				node.Permit.Domains = domainSnapshotToMap(entry.After.Domains)
+				node.Permit.Timestamp = entry.After.Timestamp
+				if !node.Permit.Revoked {
+					pll.indexAdd(node)
+				}
+			}
+		case EventRotateKey:
+			if node, ok := pll.NodeMap[entry.NodeID]; ok {
+				node.Permit.Key = pll.GenerateKey()
+				node.Permit.Timestamp = entry.After.Timestamp
+				node.Permit.LastRotated = entry.After.LastRotated
+				node.Permit.RotationCount = entry.After.RotationCount
+			}
+		case EventRevokePermit:
+			if node, ok := pll.NodeMap[entry.NodeID]; ok {
+				node.Permit.Revoked = true
+				node.Permit.Timestamp = entry.After.Timestamp
+				pll.indexRemoveDomains(node.ID, node.Permit.Domains)
+			}
+		case EventRestorePermit:
+			if node, ok := pll.NodeMap[entry.NodeID]; ok {
+				node.Permit.Revoked = false
+				node.Permit.Timestamp = entry.After.Timestamp
+				pll.indexAdd(node)
+			}
+		case EventDeletePermit:
+			if node, ok := pll.NodeMap[entry.NodeID]; ok {
+				pll.removeNode(node)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("event log: scan %s: %w", path, err)
+	}
+
+	return finishFromLog(pll, path, maxSeq)
+}
+
+// finishFromLog attaches a freshly opened (and created, if necessary)
+// EventLog at path to pll, continuing the sequence counter from maxSeq.
+func finishFromLog(pll *PermitLinkedList, path string, maxSeq int64) (*PermitLinkedList, error) {
+	el, err := NewEventLog(path)
+	if err != nil {
+		return nil, err
+	}
+	el.seq = maxSeq
+	pll.EventLog = el
+	return pll, nil
+}
+
+// Compact snapshots the current list state as a fresh sequence of
+// create/revoke events and truncates the log to just that snapshot,
+// discarding the detailed history that produced the current state.
+func (pll *PermitLinkedList) Compact() error {
+	if pll.EventLog == nil {
+		return fmt.Errorf("event log: Compact called on a list with no attached EventLog")
+	}
+
+	el := pll.EventLog
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	tmpPath := el.path + ".compact.tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("event log: create compaction temp file: %w", err)
+	}
+
+	var seq int64
+	writeEntry := func(op EventType, nodeID string, after *Permit) error {
+		seq++
+		entry := EventLogEntry{Seq: seq, Timestamp: getCurrentTimestamp(), Op: op, NodeID: nodeID, After: snapshotPermit(after)}
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		line = append(line, '\n')
+		_, err = tmp.Write(line)
+		return err
+	}
+
+	for current := pll.Head; current != nil; current = current.Next {
+		if err := writeEntry(EventCreatePermit, current.ID, current.Permit); err != nil {
+			tmp.Close()
+			return fmt.Errorf("event log: write snapshot entry: %w", err)
+		}
+		if current.Permit.Revoked {
+			if err := writeEntry(EventRevokePermit, current.ID, current.Permit); err != nil {
+				tmp.Close()
+				return fmt.Errorf("event log: write snapshot entry: %w", err)
+			}
+		}
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("event log: sync compaction temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("event log: close compaction temp file: %w", err)
+	}
+
+	if err := el.file.Close(); err != nil {
+		return fmt.Errorf("event log: close active log: %w", err)
+	}
+	if err := os.Rename(tmpPath, el.path); err != nil {
+		return fmt.Errorf("event log: replace log with compacted snapshot: %w", err)
+	}
+
+	f, err := os.OpenFile(el.path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("event log: reopen compacted log: %w", err)
+	}
+	el.file = f
+	el.seq = seq
+	return nil
+}
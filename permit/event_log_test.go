@@ -0,0 +1,120 @@
+package permit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestEventLogReplayRebuildsStructure verifies that a list rebuilt from a
+// freshly written event log has the same size, node IDs, revocation state
+// and domains as the original.
+func TestEventLogReplayRebuildsStructure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	el, err := NewEventLog(path)
+	if err != nil {
+		t.Fatalf("NewEventLog: %v", err)
+	}
+	pll := NewPermitLinkedList()
+	pll.EventLog = el
+
+	first := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+	second := pll.CreatePermit(map[Domain]bool{DomainHR: true})
+	if err := pll.RevokePermit("", "", first.ID); err != nil {
+		t.Fatalf("RevokePermit: %v", err)
+	}
+	el.Close()
+
+	replayed, err := NewPermitLinkedListFromLog(path)
+	if err != nil {
+		t.Fatalf("NewPermitLinkedListFromLog: %v", err)
+	}
+	defer replayed.EventLog.Close()
+
+	if replayed.Size != 2 {
+		t.Fatalf("replayed size = %d, want 2", replayed.Size)
+	}
+	firstNode, ok := replayed.NodeMap[first.ID]
+	if !ok {
+		t.Fatalf("replayed list missing node %s", first.ID)
+	}
+	if !firstNode.Permit.Revoked {
+		t.Fatal("replayed first node should be revoked")
+	}
+	secondNode, ok := replayed.NodeMap[second.ID]
+	if !ok {
+		t.Fatalf("replayed list missing node %s", second.ID)
+	}
+	if secondNode.Permit.Revoked {
+		t.Fatal("replayed second node should not be revoked")
+	}
+	if !secondNode.Permit.Domains[DomainHR] {
+		t.Fatal("replayed second node lost its domain")
+	}
+}
+
+// TestNewPermitLinkedListFromLogMissingFileStartsEmpty verifies that
+// replaying from a path that doesn't exist yet returns an empty list and
+// creates the log, instead of erroring out on first boot.
+func TestNewPermitLinkedListFromLogMissingFileStartsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fresh.jsonl")
+
+	pll, err := NewPermitLinkedListFromLog(path)
+	if err != nil {
+		t.Fatalf("NewPermitLinkedListFromLog on missing file: %v", err)
+	}
+	defer pll.EventLog.Close()
+
+	if pll.Size != 0 {
+		t.Fatalf("size = %d, want 0", pll.Size)
+	}
+	if pll.EventLog == nil {
+		t.Fatal("expected an attached EventLog")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected log file to have been created at %s: %v", path, err)
+	}
+}
+
+// TestCompactPreservesRotationState verifies that Compact followed by
+// replay preserves each permit's accumulated LastRotated/RotationCount
+// instead of resetting them to zero.
+func TestCompactPreservesRotationState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	el, err := NewEventLog(path)
+	if err != nil {
+		t.Fatalf("NewEventLog: %v", err)
+	}
+	pll := NewPermitLinkedList()
+	pll.EventLog = el
+
+	node := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+	for i := 0; i < 3; i++ {
+		if err := pll.RotateKey("", "", node.ID); err != nil {
+			t.Fatalf("RotateKey: %v", err)
+		}
+	}
+	wantLastRotated := node.Permit.LastRotated
+
+	if err := pll.Compact(); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	el.Close()
+
+	replayed, err := NewPermitLinkedListFromLog(path)
+	if err != nil {
+		t.Fatalf("NewPermitLinkedListFromLog after compact: %v", err)
+	}
+	defer replayed.EventLog.Close()
+
+	replayedNode, ok := replayed.NodeMap[node.ID]
+	if !ok {
+		t.Fatalf("replayed list missing node %s", node.ID)
+	}
+	if replayedNode.Permit.RotationCount != 3 {
+		t.Fatalf("RotationCount = %d, want 3", replayedNode.Permit.RotationCount)
+	}
+	if replayedNode.Permit.LastRotated != wantLastRotated {
+		t.Fatalf("LastRotated = %v, want %v", replayedNode.Permit.LastRotated, wantLastRotated)
+	}
+}
@@ -0,0 +1,569 @@
+package permit
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Permit represents a permission with domains and key
+type Permit struct {
+	Domains   map[Domain]bool
+	Key       []byte
+	Timestamp float64
+	Revoked   bool
+
+	// LastRotated is the time the key was last (re)generated. Timestamp
+	// also moves on every mutation (domain updates, revoke/restore), so
+	// LastRotated is tracked separately to answer "how old is this key"
+	// without conflating it with "when was this permit last touched".
+	LastRotated   float64
+	RotationCount int
+}
+
+// PermitNode is a node in the doubly linked list
+type PermitNode struct {
+	Permit *Permit
+	Prev   *PermitNode
+	Next   *PermitNode
+	ID     string
+}
+
+// PermitLinkedList manages permits in a doubly linked list
+type PermitLinkedList struct {
+	mu sync.RWMutex
+
+	Head    *PermitNode
+	Tail    *PermitNode
+	Size    int
+	NodeMap map[string]*PermitNode
+
+	// DomainIndex maps each domain to the active (non-revoked) permits
+	// that hold it, so FindByDomain runs in O(k) results instead of O(n)
+	// list size. Kept in sync by every mutator. BulkRotateKeys and
+	// GetActivePermits do not use it; they walk the whole list.
+	DomainIndex map[Domain]map[string]*PermitNode
+
+	// PermissionedMode gates mutating operations behind AccessController
+	// checks. Left false by default so existing callers and tests are
+	// unaffected; set it to true to enforce caller/domain authorization.
+	PermissionedMode bool
+	AccessController AccessController
+
+	// EventLog, when set, records every mutating call as an append-only
+	// audit entry. Nil by default so callers that don't need durability
+	// pay no cost.
+	EventLog *EventLog
+
+	// RotationPolicy is the schedule consulted by StartRotationScheduler
+	// and NextRotationDue. Zero value means no domain has a schedule.
+	RotationPolicy RotationPolicy
+}
+
+// NewPermitLinkedList creates a new permit linked list
+func NewPermitLinkedList() *PermitLinkedList {
+	return &PermitLinkedList{
+		NodeMap:     make(map[string]*PermitNode),
+		DomainIndex: make(map[Domain]map[string]*PermitNode),
+	}
+}
+
+// NewPermissionedPermitLinkedList creates a permit linked list with
+// PermissionedMode enabled and adminDomain as the domain that authorizes a
+// caller for any gated operation. CreatePermit is not gated.
+func NewPermissionedPermitLinkedList(adminDomain Domain) *PermitLinkedList {
+	pll := NewPermitLinkedList()
+	pll.PermissionedMode = true
+	pll.AccessController = AccessController{AdminDomain: adminDomain}
+	return pll
+}
+
+// GenerateKey generates 16 random bytes for key
+func (pll *PermitLinkedList) GenerateKey() []byte {
+	key := make([]byte, 16)
+	rand.Read(key)
+	return key
+}
+
+// generateID generates a random node ID
+func generateID() string {
+	bytes := make([]byte, 8)
+	rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
+
+// getCurrentTimestamp returns current time as float64 seconds
+func getCurrentTimestamp() float64 {
+	return float64(time.Now().UnixNano()) / 1e9
+}
+
+// CreatePermit creates a new permit and adds to the end of the list
+func (pll *PermitLinkedList) CreatePermit(domains map[Domain]bool) *PermitNode {
+	pll.mu.Lock()
+	defer pll.mu.Unlock()
+
+	now := getCurrentTimestamp()
+	permit := &Permit{
+		Domains:     domains,
+		Key:         pll.GenerateKey(),
+		Timestamp:   now,
+		Revoked:     false,
+		LastRotated: now,
+	}
+
+	newNode := &PermitNode{
+		Permit: permit,
+		ID:     generateID(),
+	}
+
+	if pll.Head == nil {
+		pll.Head = newNode
+		pll.Tail = newNode
+	} else {
+		newNode.Prev = pll.Tail
+		pll.Tail.Next = newNode
+		pll.Tail = newNode
+	}
+
+	pll.Size++
+	pll.NodeMap[newNode.ID] = newNode
+	pll.indexAdd(newNode)
+	pll.logEvent(EventCreatePermit, newNode.ID, nil, nil, permit)
+	return newNode
+}
+
+// InsertPermitAtPosition inserts a new permit at a specific position. The
+// caller must identify itself via callerID and hold requiredDomain (or the
+// configured admin domain) when the list is in PermissionedMode.
+func (pll *PermitLinkedList) InsertPermitAtPosition(callerID string, requiredDomain Domain, domains map[Domain]bool, position int) (*PermitNode, error) {
+	pll.mu.Lock()
+	defer pll.mu.Unlock()
+
+	if err := pll.authorize(callerID, requiredDomain); err != nil {
+		return nil, err
+	}
+
+	if position < 0 || position > pll.Size {
+		fmt.Printf("Invalid position %d. List size is %d\n", position, pll.Size)
+		return nil, nil
+	}
+
+	now := getCurrentTimestamp()
+	permit := &Permit{
+		Domains:     domains,
+		Key:         pll.GenerateKey(),
+		Timestamp:   now,
+		Revoked:     false,
+		LastRotated: now,
+	}
+
+	newNode := &PermitNode{
+		Permit: permit,
+		ID:     generateID(),
+	}
+
+	if position == 0 { // Insert at head
+		newNode.Next = pll.Head
+		if pll.Head != nil {
+			pll.Head.Prev = newNode
+		}
+		pll.Head = newNode
+		if pll.Tail == nil {
+			pll.Tail = newNode
+		}
+	} else if position == pll.Size { // Insert at tail
+		newNode.Prev = pll.Tail
+		if pll.Tail != nil {
+			pll.Tail.Next = newNode
+		}
+		pll.Tail = newNode
+	} else { // Insert in middle
+		current := pll.Head
+		for i := 0; i < position; i++ {
+			if current != nil {
+				current = current.Next
+			}
+		}
+
+		if current != nil {
+			newNode.Prev = current.Prev
+			newNode.Next = current
+			if current.Prev != nil {
+				current.Prev.Next = newNode
+			}
+			current.Prev = newNode
+		}
+	}
+
+	pll.Size++
+	pll.NodeMap[newNode.ID] = newNode
+	pll.indexAdd(newNode)
+	fmt.Printf("Inserted permit at position %d with ID %s\n", position, newNode.ID)
+	pll.logEvent(EventInsertPermit, newNode.ID, &position, nil, permit)
+	return newNode, nil
+}
+
+// CreateRandomPermits creates multiple permits with random domains
+func (pll *PermitLinkedList) CreateRandomPermits(count int) []*PermitNode {
+	nodes := make([]*PermitNode, count)
+	for i := 0; i < count; i++ {
+		numDomains, _ := rand.Int(rand.Reader, big.NewInt(3))
+		randomDomains := GetRandomDomains(int(numDomains.Int64()) + 1)
+		nodes[i] = pll.CreatePermit(randomDomains)
+	}
+	return nodes
+}
+
+// CreateFakeDepartmentPermits creates permits using Faker-generated department names
+func (pll *PermitLinkedList) CreateFakeDepartmentPermits(count int) []*PermitNode {
+	nodes := make([]*PermitNode, count)
+	for i := 0; i < count; i++ {
+		numDomains, _ := rand.Int(rand.Reader, big.NewInt(3))
+		fakeDepartments := CreateFakeDepartments(int(numDomains.Int64()) + 1)
+		domainSet := make(map[Domain]bool)
+		for _, dept := range fakeDepartments {
+			domainSet[dept] = true
+		}
+		nodes[i] = pll.CreatePermit(domainSet)
+	}
+	return nodes
+}
+
+// ReadPermit reads a permit by node ID, returning a detached,
+// concurrency-safe view rather than a pointer into the live list. The
+// second return value reports whether nodeID was found.
+func (pll *PermitLinkedList) ReadPermit(nodeID string) (PermitView, bool) {
+	pll.mu.RLock()
+	defer pll.mu.RUnlock()
+
+	node, ok := pll.NodeMap[nodeID]
+	if !ok {
+		return PermitView{}, false
+	}
+	return pll.viewOf(node), true
+}
+
+// UpdatePermitDomains updates domains for a specific permit. The caller must
+// identify itself via callerID and hold requiredDomain (or the configured
+// admin domain) when the list is in PermissionedMode.
+func (pll *PermitLinkedList) UpdatePermitDomains(callerID string, requiredDomain Domain, nodeID string, newDomains map[Domain]bool) error {
+	pll.mu.Lock()
+	defer pll.mu.Unlock()
+
+	if err := pll.authorize(callerID, requiredDomain); err != nil {
+		return err
+	}
+
+	node, ok := pll.NodeMap[nodeID]
+	if ok && !node.Permit.Revoked {
+		before := *node.Permit
+		pll.indexRemoveDomains(nodeID, before.Domains)
+		node.Permit.Domains = newDomains
+		node.Permit.Timestamp = getCurrentTimestamp()
+		pll.indexAdd(node)
+		domainNames := make([]string, 0, len(newDomains))
+		for d := range newDomains {
+			domainNames = append(domainNames, string(d))
+		}
+		fmt.Printf("Updated domains for node %s: %v\n", nodeID, domainNames)
+		pll.logEvent(EventUpdateDomains, nodeID, nil, &before, node.Permit)
+		return nil
+	}
+	return fmt.Errorf("update domains: node %s not found or revoked", nodeID)
+}
+
+// DeletePermit deletes a permit by node ID. The caller must identify itself
+// via callerID and hold requiredDomain (or the configured admin domain) when
+// the list is in PermissionedMode.
+func (pll *PermitLinkedList) DeletePermit(callerID string, requiredDomain Domain, nodeID string) error {
+	pll.mu.Lock()
+	defer pll.mu.Unlock()
+
+	if err := pll.authorize(callerID, requiredDomain); err != nil {
+		return err
+	}
+
+	node, ok := pll.NodeMap[nodeID]
+	if !ok {
+		fmt.Printf("Node %s not found for deletion\n", nodeID)
+		return fmt.Errorf("delete permit: node %s not found", nodeID)
+	}
+	deleted := node.Permit
+
+	pll.removeNode(node)
+	fmt.Printf("Deleted permit with ID %s\n", nodeID)
+	pll.logEvent(EventDeletePermit, nodeID, nil, deleted, nil)
+	return nil
+}
+
+// DeletePermitAtPosition deletes a permit at a specific position. The caller
+// must identify itself via callerID and hold requiredDomain (or the
+// configured admin domain) when the list is in PermissionedMode.
+func (pll *PermitLinkedList) DeletePermitAtPosition(callerID string, requiredDomain Domain, position int) error {
+	pll.mu.Lock()
+	defer pll.mu.Unlock()
+
+	if err := pll.authorize(callerID, requiredDomain); err != nil {
+		return err
+	}
+
+	if position < 0 || position >= pll.Size {
+		fmt.Printf("Invalid position %d. List size is %d\n", position, pll.Size)
+		return fmt.Errorf("delete permit at position: invalid position %d for size %d", position, pll.Size)
+	}
+
+	current := pll.Head
+	for i := 0; i < position; i++ {
+		if current != nil {
+			current = current.Next
+		}
+	}
+
+	if current == nil {
+		return fmt.Errorf("delete permit at position: position %d not found", position)
+	}
+
+	deleted := current.Permit
+	nodeID := current.ID
+	pll.removeNode(current)
+	fmt.Printf("Deleted permit with ID %s\n", nodeID)
+	pll.logEvent(EventDeletePermit, nodeID, nil, deleted, nil)
+	return nil
+}
+
+// RotateKey rotates key for a specific permit. The caller must identify
+// itself via callerID and hold requiredDomain (or the configured admin
+// domain) when the list is in PermissionedMode.
+func (pll *PermitLinkedList) RotateKey(callerID string, requiredDomain Domain, nodeID string) error {
+	pll.mu.Lock()
+	defer pll.mu.Unlock()
+
+	if err := pll.authorize(callerID, requiredDomain); err != nil {
+		return err
+	}
+
+	node, ok := pll.NodeMap[nodeID]
+	if ok && !node.Permit.Revoked {
+		before := *node.Permit
+		now := getCurrentTimestamp()
+		node.Permit.Key = pll.GenerateKey()
+		node.Permit.Timestamp = now
+		node.Permit.LastRotated = now
+		node.Permit.RotationCount++
+		fmt.Printf("Key rotated for node %s\n", nodeID)
+		pll.logEvent(EventRotateKey, nodeID, nil, &before, node.Permit)
+		return nil
+	}
+	return fmt.Errorf("rotate key: node %s not found or revoked", nodeID)
+}
+
+// RevokePermit revokes a permit (soft delete). The caller must identify
+// itself via callerID and hold requiredDomain (or the configured admin
+// domain) when the list is in PermissionedMode.
+func (pll *PermitLinkedList) RevokePermit(callerID string, requiredDomain Domain, nodeID string) error {
+	pll.mu.Lock()
+	defer pll.mu.Unlock()
+
+	if err := pll.authorize(callerID, requiredDomain); err != nil {
+		return err
+	}
+
+	node, ok := pll.NodeMap[nodeID]
+	if ok && !node.Permit.Revoked {
+		before := *node.Permit
+		node.Permit.Revoked = true
+		node.Permit.Timestamp = getCurrentTimestamp()
+		pll.indexRemoveDomains(nodeID, node.Permit.Domains)
+		fmt.Printf("Revoked permit with ID %s\n", nodeID)
+		pll.logEvent(EventRevokePermit, nodeID, nil, &before, node.Permit)
+		return nil
+	}
+	return fmt.Errorf("revoke permit: node %s not found or already revoked", nodeID)
+}
+
+// RestorePermit restores a revoked permit. The caller must identify itself
+// via callerID and hold requiredDomain (or the configured admin domain) when
+// the list is in PermissionedMode.
+func (pll *PermitLinkedList) RestorePermit(callerID string, requiredDomain Domain, nodeID string) error {
+	pll.mu.Lock()
+	defer pll.mu.Unlock()
+
+	if err := pll.authorize(callerID, requiredDomain); err != nil {
+		return err
+	}
+
+	node, ok := pll.NodeMap[nodeID]
+	if ok && node.Permit.Revoked {
+		before := *node.Permit
+		node.Permit.Revoked = false
+		node.Permit.Timestamp = getCurrentTimestamp()
+		pll.indexAdd(node)
+		fmt.Printf("Restored permit with ID %s\n", nodeID)
+		pll.logEvent(EventRestorePermit, nodeID, nil, &before, node.Permit)
+		return nil
+	}
+	return fmt.Errorf("restore permit: node %s not found or not revoked", nodeID)
+}
+
+// BulkRotateKeys rotates keys for all permits (optionally filtered by
+// domain). The caller must identify itself via callerID and hold
+// requiredDomain (or the configured admin domain) when the list is in
+// PermissionedMode.
+func (pll *PermitLinkedList) BulkRotateKeys(callerID string, requiredDomain Domain, domain *Domain) (int, error) {
+	pll.mu.Lock()
+	defer pll.mu.Unlock()
+
+	if err := pll.authorize(callerID, requiredDomain); err != nil {
+		return 0, err
+	}
+
+	rotatedCount := 0
+	current := pll.Head
+
+	for current != nil {
+		if !current.Permit.Revoked && (domain == nil || current.Permit.Domains[*domain]) {
+			before := *current.Permit
+			now := getCurrentTimestamp()
+			current.Permit.Key = pll.GenerateKey()
+			current.Permit.Timestamp = now
+			current.Permit.LastRotated = now
+			current.Permit.RotationCount++
+			pll.logEvent(EventRotateKey, current.ID, nil, &before, current.Permit)
+			rotatedCount++
+		}
+		current = current.Next
+	}
+
+	return rotatedCount, nil
+}
+
+// FindByDomain finds all active permits for a specific domain in O(k)
+// time, where k is the number of results, via the DomainIndex. Results are
+// detached, concurrency-safe views rather than pointers into the live list.
+func (pll *PermitLinkedList) FindByDomain(domain Domain) []PermitView {
+	pll.mu.RLock()
+	defer pll.mu.RUnlock()
+
+	idx := pll.DomainIndex[domain]
+	result := make([]PermitView, 0, len(idx))
+	for _, node := range idx {
+		result = append(result, pll.viewOf(node))
+	}
+	return result
+}
+
+// GetActivePermits gets all non-revoked permits as detached, concurrency-safe
+// views rather than pointers into the live list.
+func (pll *PermitLinkedList) GetActivePermits() []PermitView {
+	pll.mu.RLock()
+	defer pll.mu.RUnlock()
+
+	result := []PermitView{}
+	current := pll.Head
+
+	for current != nil {
+		if !current.Permit.Revoked {
+			result = append(result, pll.viewOf(current))
+		}
+		current = current.Next
+	}
+
+	return result
+}
+
+// DisplayList displays the entire linked list
+func (pll *PermitLinkedList) DisplayList(showRevoked bool) {
+	pll.mu.RLock()
+	defer pll.mu.RUnlock()
+
+	current := pll.Head
+	position := 0
+
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Printf("Permit Linked List (Size: %d)\n", pll.Size)
+	fmt.Println(strings.Repeat("=", 60))
+
+	if pll.Head == nil {
+		fmt.Println("List is empty")
+		return
+	}
+
+	for current != nil {
+		status := "ACTIVE"
+		if current.Permit.Revoked {
+			status = "REVOKED"
+		}
+
+		if showRevoked || !current.Permit.Revoked {
+			fmt.Printf("Position: %d\n", position)
+			fmt.Printf("Node ID: %s\n", current.ID)
+
+			domainNames := make([]string, 0, len(current.Permit.Domains))
+			for d := range current.Permit.Domains {
+				domainNames = append(domainNames, string(d))
+			}
+			fmt.Printf("Domains: %v\n", domainNames)
+
+			keyHex := hex.EncodeToString(current.Permit.Key)
+			if len(keyHex) > 16 {
+				keyHex = keyHex[:16] + "..."
+			}
+			fmt.Printf("Key: %s\n", keyHex)
+			fmt.Printf("Status: %s\n", status)
+			fmt.Printf("Timestamp: %.6f\n", current.Permit.Timestamp)
+			fmt.Println(strings.Repeat("-", 40))
+		}
+
+		current = current.Next
+		position++
+	}
+}
+
+// Statistics represents permit list statistics
+type Statistics struct {
+	TotalPermits            int
+	ActivePermits           int
+	RevokedPermits          int
+	DomainDistribution      map[string]int
+	AverageDomainsPerPermit float64
+}
+
+// GetStatistics gets statistics about the permit list
+func (pll *PermitLinkedList) GetStatistics() Statistics {
+	pll.mu.RLock()
+	defer pll.mu.RUnlock()
+
+	stats := Statistics{
+		TotalPermits:       pll.Size,
+		DomainDistribution: make(map[string]int),
+	}
+
+	current := pll.Head
+	totalDomains := 0
+
+	for current != nil {
+		if current.Permit.Revoked {
+			stats.RevokedPermits++
+		} else {
+			stats.ActivePermits++
+		}
+
+		totalDomains += len(current.Permit.Domains)
+
+		for domain := range current.Permit.Domains {
+			stats.DomainDistribution[string(domain)]++
+		}
+
+		current = current.Next
+	}
+
+	if pll.Size > 0 {
+		stats.AverageDomainsPerPermit = float64(totalDomains) / float64(pll.Size)
+	}
+
+	return stats
+}
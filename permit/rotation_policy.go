@@ -0,0 +1,168 @@
+package permit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RotationPolicy describes how old a permit's key is allowed to get before
+// it must be rotated, per domain. A permit with multiple domains is due
+// for rotation as soon as any one of its domains' schedules is exceeded.
+type RotationPolicy struct {
+	MaxKeyAge map[Domain]time.Duration
+
+	// CheckInterval controls how often StartRotationScheduler sweeps the
+	// list. Defaults to one minute if left zero.
+	CheckInterval time.Duration
+}
+
+// RotationEvent reports a key rotation performed by the RotationScheduler,
+// so callers can observe or audit automatic rotations as they happen.
+type RotationEvent struct {
+	NodeID    string
+	Domain    Domain
+	RotatedAt float64
+}
+
+// StartRotationScheduler launches a goroutine that periodically walks the
+// list and rotates the key of any active permit whose age exceeds the
+// policy for one of its domains. It stops when ctx is canceled, at which
+// point the returned channel is closed. The policy is also stored on the
+// list so NextRotationDue can answer planning queries against it.
+//
+// The returned channel is buffered but not required reading: a rotation
+// still happens even if nothing drains it, and any RotationEvent that
+// doesn't fit in the buffer is dropped (and logged) rather than blocking.
+func (pll *PermitLinkedList) StartRotationScheduler(ctx context.Context, policy RotationPolicy) <-chan RotationEvent {
+	pll.mu.Lock()
+	pll.RotationPolicy = policy
+	pll.mu.Unlock()
+
+	interval := policy.CheckInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	events := make(chan RotationEvent, 16)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				pll.sweepDueRotations(policy, events)
+			}
+		}
+	}()
+	return events
+}
+
+// sweepDueRotations rotates the key of every active permit with at least
+// one domain past its MaxKeyAge, emitting a RotationEvent per rotation.
+// Events are sent only after pll.mu is released: the channel is caller-owned
+// and bounded, and blocking on a full channel while holding the list's write
+// lock would stall every other mutating call until a consumer drains it.
+func (pll *PermitLinkedList) sweepDueRotations(policy RotationPolicy, events chan<- RotationEvent) {
+	due := pll.rotateDuePermits(policy)
+
+	for _, event := range due {
+		select {
+		case events <- event:
+		default:
+			fmt.Printf("rotation policy: dropped rotation event for node %s (consumer not keeping up)\n", event.NodeID)
+		}
+	}
+}
+
+// rotateDuePermits rotates every active permit with at least one domain past
+// its MaxKeyAge and returns the resulting events, without sending them
+// anywhere. Holds pll.mu only for the duration of the rotation itself.
+func (pll *PermitLinkedList) rotateDuePermits(policy RotationPolicy) []RotationEvent {
+	pll.mu.Lock()
+	defer pll.mu.Unlock()
+
+	now := getCurrentTimestamp()
+	var due []RotationEvent
+
+	for current := pll.Head; current != nil; current = current.Next {
+		if current.Permit.Revoked {
+			continue
+		}
+
+		dueDomain, isDue := firstDueDomain(current.Permit, policy, now)
+		if !isDue {
+			continue
+		}
+
+		before := *current.Permit
+		current.Permit.Key = pll.GenerateKey()
+		current.Permit.Timestamp = now
+		current.Permit.LastRotated = now
+		current.Permit.RotationCount++
+		pll.logEvent(EventRotateKey, current.ID, nil, &before, current.Permit)
+
+		due = append(due, RotationEvent{NodeID: current.ID, Domain: dueDomain, RotatedAt: now})
+	}
+	return due
+}
+
+// firstDueDomain returns a domain of permit whose key age exceeds the
+// policy's MaxKeyAge for that domain, if any.
+func firstDueDomain(permit *Permit, policy RotationPolicy, now float64) (Domain, bool) {
+	lastRotated := permit.LastRotated
+	if lastRotated == 0 {
+		lastRotated = permit.Timestamp
+	}
+	age := time.Duration((now - lastRotated) * float64(time.Second))
+
+	for domain := range permit.Domains {
+		maxAge, ok := policy.MaxKeyAge[domain]
+		if ok && age >= maxAge {
+			return domain, true
+		}
+	}
+	return "", false
+}
+
+// NextRotationDue reports when nodeID's permit will next be due for
+// rotation under the list's configured RotationPolicy, i.e. the earliest
+// of its domains' schedules. It errors if the node is unknown or none of
+// its domains have a configured schedule.
+func (pll *PermitLinkedList) NextRotationDue(nodeID string) (time.Time, error) {
+	pll.mu.RLock()
+	defer pll.mu.RUnlock()
+
+	node, ok := pll.NodeMap[nodeID]
+	if !ok {
+		return time.Time{}, fmt.Errorf("rotation policy: node %s not found", nodeID)
+	}
+
+	lastRotated := node.Permit.LastRotated
+	if lastRotated == 0 {
+		lastRotated = node.Permit.Timestamp
+	}
+	rotatedAt := time.Unix(0, int64(lastRotated*float64(time.Second)))
+
+	var earliest time.Time
+	found := false
+	for domain := range node.Permit.Domains {
+		maxAge, ok := pll.RotationPolicy.MaxKeyAge[domain]
+		if !ok {
+			continue
+		}
+		due := rotatedAt.Add(maxAge)
+		if !found || due.Before(earliest) {
+			earliest = due
+			found = true
+		}
+	}
+	if !found {
+		return time.Time{}, fmt.Errorf("rotation policy: node %s has no domain with a configured schedule", nodeID)
+	}
+	return earliest, nil
+}
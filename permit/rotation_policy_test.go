@@ -0,0 +1,145 @@
+package permit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRotateDuePermitsRotatesOnlyAgedKeys verifies that rotateDuePermits
+// rotates a permit whose key age exceeds its domain's MaxKeyAge and leaves
+// a permit within policy untouched.
+func TestRotateDuePermitsRotatesOnlyAgedKeys(t *testing.T) {
+	pll := NewPermitLinkedList()
+	stale := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+	fresh := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+
+	stale.Permit.LastRotated = getCurrentTimestamp() - 3600
+	staleCountBefore := stale.Permit.RotationCount
+	freshCountBefore := fresh.Permit.RotationCount
+
+	policy := RotationPolicy{MaxKeyAge: map[Domain]time.Duration{DomainFinance: time.Minute}}
+	due := pll.rotateDuePermits(policy)
+
+	if len(due) != 1 || due[0].NodeID != stale.ID {
+		t.Fatalf("rotateDuePermits due = %+v, want exactly node %s", due, stale.ID)
+	}
+	if stale.Permit.RotationCount != staleCountBefore+1 {
+		t.Fatalf("stale RotationCount = %d, want %d", stale.Permit.RotationCount, staleCountBefore+1)
+	}
+	if fresh.Permit.RotationCount != freshCountBefore {
+		t.Fatalf("fresh permit was rotated, want untouched")
+	}
+}
+
+// TestRotateDuePermitsSkipsRevoked verifies that a revoked permit is never
+// rotated even if its key age exceeds the policy.
+func TestRotateDuePermitsSkipsRevoked(t *testing.T) {
+	pll := NewPermitLinkedList()
+	node := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+	node.Permit.LastRotated = getCurrentTimestamp() - 3600
+	if err := pll.RevokePermit("", "", node.ID); err != nil {
+		t.Fatalf("RevokePermit: %v", err)
+	}
+
+	policy := RotationPolicy{MaxKeyAge: map[Domain]time.Duration{DomainFinance: time.Minute}}
+	due := pll.rotateDuePermits(policy)
+
+	if len(due) != 0 {
+		t.Fatalf("rotateDuePermits due = %+v, want none (permit is revoked)", due)
+	}
+}
+
+// TestSweepDueRotationsDropsOnFullChannel verifies that sweepDueRotations
+// never blocks on a full events channel: it drops the event instead of
+// stalling, since the channel is drained outside the list's write lock.
+func TestSweepDueRotationsDropsOnFullChannel(t *testing.T) {
+	pll := NewPermitLinkedList()
+	node := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+	node.Permit.LastRotated = getCurrentTimestamp() - 3600
+
+	policy := RotationPolicy{MaxKeyAge: map[Domain]time.Duration{DomainFinance: time.Minute}}
+	events := make(chan RotationEvent) // unbuffered and undrained: any send would block
+
+	done := make(chan struct{})
+	go func() {
+		pll.sweepDueRotations(policy, events)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sweepDueRotations blocked on a full/undrained channel")
+	}
+	if node.Permit.RotationCount != 1 {
+		t.Fatalf("RotationCount = %d, want 1 (rotation should still happen even if the event is dropped)", node.Permit.RotationCount)
+	}
+}
+
+// TestStartRotationSchedulerRotatesAndStopsOnCancel verifies that the
+// scheduler goroutine rotates due permits on its tick and closes its
+// channel once its context is canceled.
+func TestStartRotationSchedulerRotatesAndStopsOnCancel(t *testing.T) {
+	pll := NewPermitLinkedList()
+	node := pll.CreatePermit(map[Domain]bool{DomainFinance: true})
+	node.Permit.LastRotated = getCurrentTimestamp() - 3600
+
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := RotationPolicy{
+		MaxKeyAge:     map[Domain]time.Duration{DomainFinance: time.Minute},
+		CheckInterval: 10 * time.Millisecond,
+	}
+	events := pll.StartRotationScheduler(ctx, policy)
+
+	select {
+	case event := <-events:
+		if event.NodeID != node.ID {
+			t.Fatalf("rotation event for node %s, want %s", event.NodeID, node.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a rotation event")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected events channel to be closed after ctx cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for events channel to close")
+	}
+}
+
+// TestNextRotationDue verifies the earliest-due-domain calculation and its
+// error paths.
+func TestNextRotationDue(t *testing.T) {
+	pll := NewPermitLinkedList()
+	node := pll.CreatePermit(map[Domain]bool{DomainFinance: true, DomainHR: true})
+	pll.RotationPolicy = RotationPolicy{
+		MaxKeyAge: map[Domain]time.Duration{
+			DomainFinance: time.Hour,
+			DomainHR:      time.Minute,
+		},
+	}
+
+	due, err := pll.NextRotationDue(node.ID)
+	if err != nil {
+		t.Fatalf("NextRotationDue: %v", err)
+	}
+	rotatedAt := time.Unix(0, int64(node.Permit.Timestamp*float64(time.Second)))
+	wantEarliest := rotatedAt.Add(time.Minute)
+	if !due.Equal(wantEarliest) {
+		t.Fatalf("NextRotationDue = %v, want %v (DomainHR's shorter schedule)", due, wantEarliest)
+	}
+
+	if _, err := pll.NextRotationDue("missing"); err == nil {
+		t.Fatal("NextRotationDue on unknown node: want error, got nil")
+	}
+
+	unscheduled := pll.CreatePermit(map[Domain]bool{DomainIT: true})
+	if _, err := pll.NextRotationDue(unscheduled.ID); err == nil {
+		t.Fatal("NextRotationDue on a permit with no scheduled domain: want error, got nil")
+	}
+}
@@ -0,0 +1,199 @@
+package permit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ursa-mikail/double_linked_list_chained_permit/proto/permitpb"
+)
+
+// PermitGRPCService adapts a PermitLinkedList to the permitpb.PermitServiceServer
+// interface so it can be driven from a separate client process instead of
+// only in-process.
+type PermitGRPCService struct {
+	permitpb.UnimplementedPermitServiceServer
+
+	pll *PermitLinkedList
+
+	subMu       sync.Mutex
+	subscribers map[chan *permitpb.PermitEvent]struct{}
+}
+
+// NewPermitGRPCService wraps pll for serving over gRPC.
+func NewPermitGRPCService(pll *PermitLinkedList) *PermitGRPCService {
+	return &PermitGRPCService{
+		pll:         pll,
+		subscribers: make(map[chan *permitpb.PermitEvent]struct{}),
+	}
+}
+
+func domainsToStrings(domains map[Domain]bool) []string {
+	result := make([]string, 0, len(domains))
+	for d := range domains {
+		result = append(result, string(d))
+	}
+	return result
+}
+
+func stringsToDomains(domains []string) map[Domain]bool {
+	result := make(map[Domain]bool, len(domains))
+	for _, d := range domains {
+		result[Domain(d)] = true
+	}
+	return result
+}
+
+func toPermitReply(nodeID string, permit *Permit) *permitpb.PermitReply {
+	return &permitpb.PermitReply{
+		NodeID:    nodeID,
+		Domains:   domainsToStrings(permit.Domains),
+		Revoked:   permit.Revoked,
+		Timestamp: permit.Timestamp,
+	}
+}
+
+func viewToPermitReply(view PermitView) *permitpb.PermitReply {
+	return &permitpb.PermitReply{
+		NodeID:    view.ID,
+		Domains:   view.Domains,
+		Revoked:   view.Revoked,
+		Timestamp: view.Timestamp,
+	}
+}
+
+func callerOf(c *permitpb.CallerContext) (string, Domain) {
+	if c == nil {
+		return "", ""
+	}
+	return c.CallerID, Domain(c.RequiredDomain)
+}
+
+func (s *PermitGRPCService) publish(event *permitpb.PermitEvent) {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+	for ch := range s.subscribers {
+		select {
+		case ch <- event:
+		default: // drop if the subscriber isn't keeping up
+		}
+	}
+}
+
+func (s *PermitGRPCService) CreatePermit(ctx context.Context, req *permitpb.CreatePermitRequest) (*permitpb.PermitReply, error) {
+	node := s.pll.CreatePermit(stringsToDomains(req.Domains))
+	return toPermitReply(node.ID, node.Permit), nil
+}
+
+func (s *PermitGRPCService) InsertAt(ctx context.Context, req *permitpb.InsertAtRequest) (*permitpb.PermitReply, error) {
+	callerID, requiredDomain := callerOf(req.Caller)
+	node, err := s.pll.InsertPermitAtPosition(callerID, requiredDomain, stringsToDomains(req.Domains), int(req.Position))
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, fmt.Errorf("insert at: position %d rejected", req.Position)
+	}
+	return toPermitReply(node.ID, node.Permit), nil
+}
+
+func (s *PermitGRPCService) GetPermit(ctx context.Context, req *permitpb.GetPermitRequest) (*permitpb.PermitReply, error) {
+	view, ok := s.pll.ReadPermit(req.NodeID)
+	if !ok {
+		return nil, fmt.Errorf("get permit: node %s not found", req.NodeID)
+	}
+	return viewToPermitReply(view), nil
+}
+
+func (s *PermitGRPCService) ListByDomain(ctx context.Context, req *permitpb.ListByDomainRequest) (*permitpb.ListByDomainReply, error) {
+	views := s.pll.FindByDomain(Domain(req.Domain))
+	reply := &permitpb.ListByDomainReply{Permits: make([]*permitpb.PermitReply, 0, len(views))}
+	for _, view := range views {
+		reply.Permits = append(reply.Permits, viewToPermitReply(view))
+	}
+	return reply, nil
+}
+
+func (s *PermitGRPCService) UpdateDomains(ctx context.Context, req *permitpb.UpdateDomainsRequest) (*permitpb.StatusReply, error) {
+	callerID, requiredDomain := callerOf(req.Caller)
+	if err := s.pll.UpdatePermitDomains(callerID, requiredDomain, req.NodeID, stringsToDomains(req.Domains)); err != nil {
+		return &permitpb.StatusReply{Ok: false, Error: err.Error()}, nil
+	}
+	return &permitpb.StatusReply{Ok: true}, nil
+}
+
+func (s *PermitGRPCService) RotateKey(ctx context.Context, req *permitpb.NodeRequest) (*permitpb.StatusReply, error) {
+	callerID, requiredDomain := callerOf(req.Caller)
+	if err := s.pll.RotateKey(callerID, requiredDomain, req.NodeID); err != nil {
+		return &permitpb.StatusReply{Ok: false, Error: err.Error()}, nil
+	}
+	s.publish(&permitpb.PermitEvent{NodeID: req.NodeID, Op: string(EventRotateKey), Timestamp: getCurrentTimestamp()})
+	return &permitpb.StatusReply{Ok: true}, nil
+}
+
+func (s *PermitGRPCService) BulkRotateKeys(ctx context.Context, req *permitpb.BulkRotateKeysRequest) (*permitpb.BulkRotateKeysReply, error) {
+	callerID, requiredDomain := callerOf(req.Caller)
+	var domain *Domain
+	if req.Domain != nil {
+		d := Domain(*req.Domain)
+		domain = &d
+	}
+	count, err := s.pll.BulkRotateKeys(callerID, requiredDomain, domain)
+	if err != nil {
+		return nil, err
+	}
+	return &permitpb.BulkRotateKeysReply{RotatedCount: int32(count)}, nil
+}
+
+func (s *PermitGRPCService) Revoke(ctx context.Context, req *permitpb.NodeRequest) (*permitpb.StatusReply, error) {
+	callerID, requiredDomain := callerOf(req.Caller)
+	if err := s.pll.RevokePermit(callerID, requiredDomain, req.NodeID); err != nil {
+		return &permitpb.StatusReply{Ok: false, Error: err.Error()}, nil
+	}
+	s.publish(&permitpb.PermitEvent{NodeID: req.NodeID, Op: string(EventRevokePermit), Timestamp: getCurrentTimestamp()})
+	return &permitpb.StatusReply{Ok: true}, nil
+}
+
+func (s *PermitGRPCService) Restore(ctx context.Context, req *permitpb.NodeRequest) (*permitpb.StatusReply, error) {
+	callerID, requiredDomain := callerOf(req.Caller)
+	if err := s.pll.RestorePermit(callerID, requiredDomain, req.NodeID); err != nil {
+		return &permitpb.StatusReply{Ok: false, Error: err.Error()}, nil
+	}
+	s.publish(&permitpb.PermitEvent{NodeID: req.NodeID, Op: string(EventRestorePermit), Timestamp: getCurrentTimestamp()})
+	return &permitpb.StatusReply{Ok: true}, nil
+}
+
+func (s *PermitGRPCService) Delete(ctx context.Context, req *permitpb.NodeRequest) (*permitpb.StatusReply, error) {
+	callerID, requiredDomain := callerOf(req.Caller)
+	if err := s.pll.DeletePermit(callerID, requiredDomain, req.NodeID); err != nil {
+		return &permitpb.StatusReply{Ok: false, Error: err.Error()}, nil
+	}
+	return &permitpb.StatusReply{Ok: true}, nil
+}
+
+// WatchEvents streams rotation and revocation notifications to the caller
+// until the stream's context is canceled.
+func (s *PermitGRPCService) WatchEvents(req *permitpb.WatchEventsRequest, stream permitpb.PermitService_WatchEventsServer) error {
+	ch := make(chan *permitpb.PermitEvent, 16)
+
+	s.subMu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.subMu.Unlock()
+
+	defer func() {
+		s.subMu.Lock()
+		delete(s.subscribers, ch)
+		s.subMu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case event := <-ch:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
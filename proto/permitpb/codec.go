@@ -0,0 +1,40 @@
+package permitpb
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// jsonCodec marshals the hand-rolled message structs in this package as
+// JSON. grpc-go's built-in "proto" codec requires every message to
+// implement proto.Message, which these stand-in types don't (see the
+// package doc comment); registering this codec under the same name makes
+// CreatePermitRequest, PermitReply, and friends actually marshal over the
+// wire. This is NOT wire-compatible with a real protobuf client or server —
+// it only interoperates with another binary built from this package.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("permitpb: marshal %T: %w", v, err)
+	}
+	return data, nil
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("permitpb: unmarshal %T: %w", v, err)
+	}
+	return nil
+}
+
+func (jsonCodec) Name() string {
+	return "proto"
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
@@ -0,0 +1,491 @@
+// Package permitpb holds the Go types for proto/permit.proto.
+//
+// In a normal build these would be produced by running:
+//
+//	protoc --go_out=. --go-grpc_out=. proto/permit.proto
+//
+// protoc and the protoc-gen-go / protoc-gen-go-grpc plugins aren't
+// available in this environment, so this file is hand-maintained as a
+// stand-in: plain message structs plus the client/server interfaces
+// protoc-gen-go-grpc would emit. Regenerate and delete this file once
+// the proto toolchain is available.
+//
+// None of the message structs implement proto.Message, so they can't go
+// through grpc-go's default protobuf codec. codec.go registers a JSON codec
+// under the "proto" name to make them marshal instead; see its doc comment
+// for what that does and doesn't buy you.
+package permitpb
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+)
+
+type CallerContext struct {
+	CallerID       string
+	RequiredDomain string
+}
+
+type CreatePermitRequest struct {
+	Caller  *CallerContext
+	Domains []string
+}
+
+type InsertAtRequest struct {
+	Caller   *CallerContext
+	Domains  []string
+	Position int32
+}
+
+type GetPermitRequest struct {
+	NodeID string
+}
+
+type ListByDomainRequest struct {
+	Domain string
+}
+
+type UpdateDomainsRequest struct {
+	Caller  *CallerContext
+	NodeID  string
+	Domains []string
+}
+
+type NodeRequest struct {
+	Caller *CallerContext
+	NodeID string
+}
+
+type BulkRotateKeysRequest struct {
+	Caller *CallerContext
+	Domain *string
+}
+
+type BulkRotateKeysReply struct {
+	RotatedCount int32
+}
+
+type PermitReply struct {
+	NodeID    string
+	Domains   []string
+	Revoked   bool
+	Timestamp float64
+}
+
+type ListByDomainReply struct {
+	Permits []*PermitReply
+}
+
+type StatusReply struct {
+	Ok    bool
+	Error string
+}
+
+type WatchEventsRequest struct{}
+
+type PermitEvent struct {
+	NodeID    string
+	Domain    string
+	Op        string
+	Timestamp float64
+}
+
+// PermitServiceServer is the server API for PermitService.
+type PermitServiceServer interface {
+	CreatePermit(context.Context, *CreatePermitRequest) (*PermitReply, error)
+	InsertAt(context.Context, *InsertAtRequest) (*PermitReply, error)
+	GetPermit(context.Context, *GetPermitRequest) (*PermitReply, error)
+	ListByDomain(context.Context, *ListByDomainRequest) (*ListByDomainReply, error)
+	UpdateDomains(context.Context, *UpdateDomainsRequest) (*StatusReply, error)
+	RotateKey(context.Context, *NodeRequest) (*StatusReply, error)
+	BulkRotateKeys(context.Context, *BulkRotateKeysRequest) (*BulkRotateKeysReply, error)
+	Revoke(context.Context, *NodeRequest) (*StatusReply, error)
+	Restore(context.Context, *NodeRequest) (*StatusReply, error)
+	Delete(context.Context, *NodeRequest) (*StatusReply, error)
+	WatchEvents(*WatchEventsRequest, PermitService_WatchEventsServer) error
+}
+
+// PermitService_WatchEventsServer is the server-streaming handle for
+// WatchEvents, mirroring the protoc-gen-go-grpc generated interface.
+type PermitService_WatchEventsServer interface {
+	Send(*PermitEvent) error
+	grpc.ServerStream
+}
+
+// UnimplementedPermitServiceServer can be embedded in a server
+// implementation to get forward-compatible behavior: methods added to the
+// interface later return Unimplemented instead of failing to compile.
+type UnimplementedPermitServiceServer struct{}
+
+func (UnimplementedPermitServiceServer) CreatePermit(context.Context, *CreatePermitRequest) (*PermitReply, error) {
+	return nil, errUnimplemented("CreatePermit")
+}
+func (UnimplementedPermitServiceServer) InsertAt(context.Context, *InsertAtRequest) (*PermitReply, error) {
+	return nil, errUnimplemented("InsertAt")
+}
+func (UnimplementedPermitServiceServer) GetPermit(context.Context, *GetPermitRequest) (*PermitReply, error) {
+	return nil, errUnimplemented("GetPermit")
+}
+func (UnimplementedPermitServiceServer) ListByDomain(context.Context, *ListByDomainRequest) (*ListByDomainReply, error) {
+	return nil, errUnimplemented("ListByDomain")
+}
+func (UnimplementedPermitServiceServer) UpdateDomains(context.Context, *UpdateDomainsRequest) (*StatusReply, error) {
+	return nil, errUnimplemented("UpdateDomains")
+}
+func (UnimplementedPermitServiceServer) RotateKey(context.Context, *NodeRequest) (*StatusReply, error) {
+	return nil, errUnimplemented("RotateKey")
+}
+func (UnimplementedPermitServiceServer) BulkRotateKeys(context.Context, *BulkRotateKeysRequest) (*BulkRotateKeysReply, error) {
+	return nil, errUnimplemented("BulkRotateKeys")
+}
+func (UnimplementedPermitServiceServer) Revoke(context.Context, *NodeRequest) (*StatusReply, error) {
+	return nil, errUnimplemented("Revoke")
+}
+func (UnimplementedPermitServiceServer) Restore(context.Context, *NodeRequest) (*StatusReply, error) {
+	return nil, errUnimplemented("Restore")
+}
+func (UnimplementedPermitServiceServer) Delete(context.Context, *NodeRequest) (*StatusReply, error) {
+	return nil, errUnimplemented("Delete")
+}
+func (UnimplementedPermitServiceServer) WatchEvents(*WatchEventsRequest, PermitService_WatchEventsServer) error {
+	return errUnimplemented("WatchEvents")
+}
+
+func errUnimplemented(method string) error {
+	return fmt.Errorf("permitpb: method %s not implemented", method)
+}
+
+// PermitServiceClient is the client API for PermitService.
+type PermitServiceClient interface {
+	CreatePermit(ctx context.Context, in *CreatePermitRequest, opts ...grpc.CallOption) (*PermitReply, error)
+	InsertAt(ctx context.Context, in *InsertAtRequest, opts ...grpc.CallOption) (*PermitReply, error)
+	GetPermit(ctx context.Context, in *GetPermitRequest, opts ...grpc.CallOption) (*PermitReply, error)
+	ListByDomain(ctx context.Context, in *ListByDomainRequest, opts ...grpc.CallOption) (*ListByDomainReply, error)
+	UpdateDomains(ctx context.Context, in *UpdateDomainsRequest, opts ...grpc.CallOption) (*StatusReply, error)
+	RotateKey(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*StatusReply, error)
+	BulkRotateKeys(ctx context.Context, in *BulkRotateKeysRequest, opts ...grpc.CallOption) (*BulkRotateKeysReply, error)
+	Revoke(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*StatusReply, error)
+	Restore(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*StatusReply, error)
+	Delete(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*StatusReply, error)
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (PermitService_WatchEventsClient, error)
+}
+
+// PermitService_WatchEventsClient is the client-side handle for the
+// server-streaming WatchEvents, mirroring the protoc-gen-go-grpc generated interface.
+type PermitService_WatchEventsClient interface {
+	Recv() (*PermitEvent, error)
+	grpc.ClientStream
+}
+
+// NewPermitServiceClient returns a PermitServiceClient backed by cc. In
+// generated code this constructor and permitServiceClient are produced by
+// protoc-gen-go-grpc from the service's ServiceDesc.
+func NewPermitServiceClient(cc grpc.ClientConnInterface) PermitServiceClient {
+	return &permitServiceClient{cc}
+}
+
+type permitServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func (c *permitServiceClient) CreatePermit(ctx context.Context, in *CreatePermitRequest, opts ...grpc.CallOption) (*PermitReply, error) {
+	out := new(PermitReply)
+	if err := c.cc.Invoke(ctx, "/permit.PermitService/CreatePermit", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitServiceClient) InsertAt(ctx context.Context, in *InsertAtRequest, opts ...grpc.CallOption) (*PermitReply, error) {
+	out := new(PermitReply)
+	if err := c.cc.Invoke(ctx, "/permit.PermitService/InsertAt", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitServiceClient) GetPermit(ctx context.Context, in *GetPermitRequest, opts ...grpc.CallOption) (*PermitReply, error) {
+	out := new(PermitReply)
+	if err := c.cc.Invoke(ctx, "/permit.PermitService/GetPermit", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitServiceClient) ListByDomain(ctx context.Context, in *ListByDomainRequest, opts ...grpc.CallOption) (*ListByDomainReply, error) {
+	out := new(ListByDomainReply)
+	if err := c.cc.Invoke(ctx, "/permit.PermitService/ListByDomain", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitServiceClient) UpdateDomains(ctx context.Context, in *UpdateDomainsRequest, opts ...grpc.CallOption) (*StatusReply, error) {
+	out := new(StatusReply)
+	if err := c.cc.Invoke(ctx, "/permit.PermitService/UpdateDomains", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitServiceClient) RotateKey(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*StatusReply, error) {
+	out := new(StatusReply)
+	if err := c.cc.Invoke(ctx, "/permit.PermitService/RotateKey", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitServiceClient) BulkRotateKeys(ctx context.Context, in *BulkRotateKeysRequest, opts ...grpc.CallOption) (*BulkRotateKeysReply, error) {
+	out := new(BulkRotateKeysReply)
+	if err := c.cc.Invoke(ctx, "/permit.PermitService/BulkRotateKeys", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitServiceClient) Revoke(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*StatusReply, error) {
+	out := new(StatusReply)
+	if err := c.cc.Invoke(ctx, "/permit.PermitService/Revoke", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitServiceClient) Restore(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*StatusReply, error) {
+	out := new(StatusReply)
+	if err := c.cc.Invoke(ctx, "/permit.PermitService/Restore", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitServiceClient) Delete(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*StatusReply, error) {
+	out := new(StatusReply)
+	if err := c.cc.Invoke(ctx, "/permit.PermitService/Delete", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitServiceClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (PermitService_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &permitServiceServiceDesc.Streams[0], "/permit.PermitService/WatchEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &permitServiceWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type permitServiceWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (c *permitServiceWatchEventsClient) Recv() (*PermitEvent, error) {
+	m := new(PermitEvent)
+	if err := c.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RegisterPermitServiceServer registers srv as the PermitService
+// implementation on s. In generated code this is produced by
+// protoc-gen-go-grpc from the service's ServiceDesc, including a
+// grpc.MethodDesc per unary RPC (handlers below) alongside the WatchEvents
+// grpc.StreamDesc.
+func RegisterPermitServiceServer(s grpc.ServiceRegistrar, srv PermitServiceServer) {
+	s.RegisterService(&permitServiceServiceDesc, srv)
+}
+
+func permitServiceCreatePermitHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePermitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServiceServer).CreatePermit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/permit.PermitService/CreatePermit"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServiceServer).CreatePermit(ctx, req.(*CreatePermitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func permitServiceInsertAtHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServiceServer).InsertAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/permit.PermitService/InsertAt"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServiceServer).InsertAt(ctx, req.(*InsertAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func permitServiceGetPermitHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPermitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServiceServer).GetPermit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/permit.PermitService/GetPermit"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServiceServer).GetPermit(ctx, req.(*GetPermitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func permitServiceListByDomainHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListByDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServiceServer).ListByDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/permit.PermitService/ListByDomain"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServiceServer).ListByDomain(ctx, req.(*ListByDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func permitServiceUpdateDomainsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServiceServer).UpdateDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/permit.PermitService/UpdateDomains"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServiceServer).UpdateDomains(ctx, req.(*UpdateDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func permitServiceRotateKeyHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServiceServer).RotateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/permit.PermitService/RotateKey"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServiceServer).RotateKey(ctx, req.(*NodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func permitServiceBulkRotateKeysHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkRotateKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServiceServer).BulkRotateKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/permit.PermitService/BulkRotateKeys"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServiceServer).BulkRotateKeys(ctx, req.(*BulkRotateKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func permitServiceRevokeHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServiceServer).Revoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/permit.PermitService/Revoke"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServiceServer).Revoke(ctx, req.(*NodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func permitServiceRestoreHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/permit.PermitService/Restore"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServiceServer).Restore(ctx, req.(*NodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func permitServiceDeleteHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/permit.PermitService/Delete"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServiceServer).Delete(ctx, req.(*NodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var permitServiceServiceDesc = grpc.ServiceDesc{
+	ServiceName: "permit.PermitService",
+	HandlerType: (*PermitServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "CreatePermit", Handler: permitServiceCreatePermitHandler},
+		{MethodName: "InsertAt", Handler: permitServiceInsertAtHandler},
+		{MethodName: "GetPermit", Handler: permitServiceGetPermitHandler},
+		{MethodName: "ListByDomain", Handler: permitServiceListByDomainHandler},
+		{MethodName: "UpdateDomains", Handler: permitServiceUpdateDomainsHandler},
+		{MethodName: "RotateKey", Handler: permitServiceRotateKeyHandler},
+		{MethodName: "BulkRotateKeys", Handler: permitServiceBulkRotateKeysHandler},
+		{MethodName: "Revoke", Handler: permitServiceRevokeHandler},
+		{MethodName: "Restore", Handler: permitServiceRestoreHandler},
+		{MethodName: "Delete", Handler: permitServiceDeleteHandler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			ServerStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return srv.(PermitServiceServer).WatchEvents(new(WatchEventsRequest), &permitServiceWatchEventsServer{stream})
+			},
+		},
+	},
+}
+
+type permitServiceWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (s *permitServiceWatchEventsServer) Send(event *PermitEvent) error {
+	return s.ServerStream.SendMsg(event)
+}